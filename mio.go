@@ -32,6 +32,18 @@ type Histogram interface {
 	Variance() float64
 }
 
+// Meter interface wraps a subset of methods of metrics.Meter interface so it
+// can be used without type conversion. It tracks a rate of events over time,
+// such as bytes transferred per second, decayed using an EWMA over the last
+// 1, 5 and 15 minutes.
+type Meter interface {
+	Count() int64
+	Mark(int64)
+	Rate1() float64
+	Rate5() float64
+	Rate15() float64
+}
+
 // SelfCleaningHistogram wraps metrics.Histogram, adding self-cleaning feature
 // if no samples were registered for a specified time. SelfCleaningHistogram
 // also implements Registrar interface, call Register() method to announce
@@ -61,6 +73,14 @@ type Registrar interface {
 	Shutdown()
 }
 
+// Registry is satisfied by mio/report.Registry; it lets NewWriterWithRegistry
+// and NewReaderWithRegistry auto-register their Histogram under a name and
+// set of tags, and unregister it once the stream is closed.
+type Registry interface {
+	Register(name string, h Histogram, tags map[string]string)
+	Unregister(name string)
+}
+
 // NewSelfCleaningHistogram returns SelfCleaningHistogram wrapping specified
 // histogram; its self-cleaning period set to delay.
 func NewSelfCleaningHistogram(histogram Histogram, delay time.Duration) *SelfCleaningHistogram {
@@ -131,3 +151,29 @@ func (h *SelfCleaningHistogram) Shutdown() {
 		close(h.q)
 	}
 }
+
+// SelfCleaningMeter wraps a Meter purely so it satisfies the Registrar
+// interface, letting a single Meter be passed wherever SelfCleaningHistogram
+// could be, e.g. shared over multiple Writers/Readers. Unlike a histogram's
+// reservoir, a Meter's rates already decay on their own via EWMA ticking,
+// so there is no sample pool to clear and no usage count worth tracking;
+// Register, Done and Shutdown are all no-ops.
+type SelfCleaningMeter struct {
+	Meter
+}
+
+// NewSelfCleaningMeter returns a SelfCleaningMeter wrapping the specified
+// meter.
+func NewSelfCleaningMeter(meter Meter) *SelfCleaningMeter {
+	return &SelfCleaningMeter{Meter: meter}
+}
+
+// Register implements Registrar interface. See SelfCleaningMeter's doc
+// comment for why this is a no-op.
+func (m *SelfCleaningMeter) Register() {}
+
+// Done implements Registrar interface; see Register.
+func (m *SelfCleaningMeter) Done() {}
+
+// Shutdown implements Registrar interface; see Register.
+func (m *SelfCleaningMeter) Shutdown() {}
@@ -0,0 +1,93 @@
+package mio
+
+import (
+	"io"
+	"time"
+)
+
+// LabeledHistogram mirrors go-kit's metrics.Histogram interface: a single
+// shared metric hands out per-label-value children via With, each of which
+// records observations, in seconds, through Observe. It lets one shared
+// labeled metric (a Prometheus vector, a go-kit metric, or an adapted
+// mio.Histogram) back many per-stream Writer/Reader instances without
+// allocating a reservoir per stream.
+type LabeledHistogram interface {
+	With(labelValues ...string) LabeledHistogram
+	Observe(float64)
+}
+
+// observeOnlyHistogram adapts a LabeledHistogram child, already scoped to
+// its label values via With, to the Histogram interface using only
+// Update/Observe. It keeps no local reservoir, so its statistical query
+// methods always report zero: with many per-stream Writer/Reader instances
+// sharing one labeled metric, allocating a reservoir per stream would
+// defeat the point of sharing it. This is what backs NewWriterWithLabels
+// and NewReaderWithLabels.
+type observeOnlyHistogram struct {
+	lh LabeledHistogram
+}
+
+func (h *observeOnlyHistogram) Update(ns int64)                    { h.lh.Observe(float64(ns) / 1e9) }
+func (h *observeOnlyHistogram) Clear()                             {}
+func (h *observeOnlyHistogram) Count() int64                       { return 0 }
+func (h *observeOnlyHistogram) Max() int64                         { return 0 }
+func (h *observeOnlyHistogram) Mean() float64                      { return 0 }
+func (h *observeOnlyHistogram) Min() int64                         { return 0 }
+func (h *observeOnlyHistogram) Percentile(float64) float64         { return 0 }
+func (h *observeOnlyHistogram) Percentiles(ps []float64) []float64 { return make([]float64, len(ps)) }
+func (h *observeOnlyHistogram) StdDev() float64                    { return 0 }
+func (h *observeOnlyHistogram) Variance() float64                  { return 0 }
+
+var _ Histogram = (*observeOnlyHistogram)(nil)
+
+// NewWriterWithLabels attaches lh to writer, selecting its child metric for
+// label values lvs once at construction time; every subsequent Write
+// observes into that same child.
+func NewWriterWithLabels(writer io.Writer, lh LabeledHistogram, lvs ...string) *Writer {
+	return NewWriter(writer, &observeOnlyHistogram{lh: lh.With(lvs...)})
+}
+
+// NewReaderWithLabels attaches lh to reader, selecting its child metric for
+// label values lvs once at construction time; every subsequent Read
+// observes into that same child.
+func NewReaderWithLabels(reader io.Reader, lh LabeledHistogram, lvs ...string) *Reader {
+	return NewReader(reader, &observeOnlyHistogram{lh: lh.With(lvs...)})
+}
+
+// HistogramToLabeled adapts h to the LabeledHistogram interface, so a plain
+// Histogram (e.g. the existing rcrowley-backed one) can be used wherever a
+// labeled metric is expected. Because h has no child metrics, With returns
+// the adapter itself, ignoring the label values passed to it.
+func HistogramToLabeled(h Histogram) LabeledHistogram {
+	return &labeledFromHistogram{h: h}
+}
+
+type labeledFromHistogram struct {
+	h Histogram
+}
+
+func (l *labeledFromHistogram) With(labelValues ...string) LabeledHistogram { return l }
+func (l *labeledFromHistogram) Observe(v float64)                           { l.h.Update(int64(v * 1e9)) }
+
+var _ LabeledHistogram = (*labeledFromHistogram)(nil)
+
+// LabeledToHistogram adapts lh, a LabeledHistogram already scoped to its
+// label values (e.g. the result of a With call), to the Histogram
+// interface. Update observes into lh; statistical queries are served from
+// an embedded ResettingHistogram kept for compatibility, mirroring how
+// mio/prom.Histogram bridges the same gap for Prometheus vectors.
+func LabeledToHistogram(lh LabeledHistogram, interval time.Duration) Histogram {
+	return &histogramFromLabeled{ResettingHistogram: NewResettingHistogram(interval), lh: lh}
+}
+
+type histogramFromLabeled struct {
+	*ResettingHistogram
+	lh LabeledHistogram
+}
+
+func (h *histogramFromLabeled) Update(ns int64) {
+	h.ResettingHistogram.Update(ns)
+	h.lh.Observe(float64(ns) / 1e9)
+}
+
+var _ Histogram = (*histogramFromLabeled)(nil)
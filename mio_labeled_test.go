@@ -0,0 +1,77 @@
+package mio
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeLabeledHistogram is a minimal LabeledHistogram used to verify that
+// NewWriterWithLabels/NewReaderWithLabels select the right child metric at
+// construction time and observe into it on every Write/Read.
+type fakeLabeledHistogram struct {
+	labelValues []string
+	observed    *[]float64
+	children    map[string]*fakeLabeledHistogram
+}
+
+func newFakeLabeledHistogram() *fakeLabeledHistogram {
+	return &fakeLabeledHistogram{observed: new([]float64), children: make(map[string]*fakeLabeledHistogram)}
+}
+
+func (f *fakeLabeledHistogram) With(lvs ...string) LabeledHistogram {
+	key := strings.Join(lvs, ",")
+	child, ok := f.children[key]
+	if !ok {
+		child = &fakeLabeledHistogram{labelValues: lvs, observed: new([]float64)}
+		f.children[key] = child
+	}
+	return child
+}
+
+func (f *fakeLabeledHistogram) Observe(v float64) {
+	*f.observed = append(*f.observed, v)
+}
+
+func TestWriterWithLabels(t *testing.T) {
+	lh := newFakeLabeledHistogram()
+	mw := NewWriterWithLabels(ioutil.Discard, lh, "/foo", "write")
+	if _, err := mw.Write([]byte("hello")); err != nil {
+		t.Fatal("write failed:", err)
+	}
+	child := lh.children["/foo,write"]
+	if child == nil {
+		t.Fatal("expected child metric for labels /foo,write")
+	}
+	if len(*child.observed) != 1 {
+		t.Fatalf("observed %d samples on labeled child, want 1", len(*child.observed))
+	}
+	if len(*lh.observed) != 0 {
+		t.Fatal("expected no observations on the unlabeled parent metric")
+	}
+}
+
+func TestHistogramLabeledRoundTrip(t *testing.T) {
+	lh := newFakeLabeledHistogram()
+	h := LabeledToHistogram(lh.With("/bar", "read"), 20*time.Millisecond)
+	defer h.(Registrar).Shutdown()
+	h.Update(2_000_000) // 2ms
+
+	child := lh.children["/bar,read"]
+	if child == nil || len(*child.observed) != 1 {
+		t.Fatal("expected LabeledToHistogram to observe into the selected child")
+	}
+
+	backH := HistogramToLabeled(h)
+	backH.With("ignored").Observe(0.5)
+	if len(*child.observed) != 2 {
+		t.Fatalf("observations on the labeled child after HistogramToLabeled round trip = %d, want 2", len(*child.observed))
+	}
+
+	// Wake between the first tick (20ms) and the second (40ms).
+	time.Sleep(30 * time.Millisecond)
+	if h.Count() != 2 {
+		t.Fatalf("Count() after tick = %d, want 2", h.Count())
+	}
+}
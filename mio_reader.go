@@ -10,6 +10,9 @@ import (
 type Reader struct {
 	io.Reader
 	h      Histogram
+	m      Meter
+	reg    Registry
+	name   string
 	closed bool
 }
 
@@ -17,33 +20,121 @@ type Reader struct {
 // io.Reader. If histogram implements Registrar interface, this would also call
 // its Register() method.
 func NewReader(reader io.Reader, h Histogram) *Reader {
+	return NewReaderWithMeter(reader, h, nil)
+}
+
+// NewReaderWithMeter attaches provided histogram and meter to reader,
+// returning new io.Reader. Each successful Read samples its latency into h
+// and marks its byte count into m. If h or m implements Registrar interface,
+// this would also call its Register() method; m may be nil to skip
+// throughput tracking.
+func NewReaderWithMeter(reader io.Reader, h Histogram, m Meter) *Reader {
 	mr := &Reader{
 		Reader: reader,
 		h:      h,
+		m:      m,
 	}
 	if r, ok := h.(Registrar); ok {
 		r.Register()
 	}
+	if r, ok := m.(Registrar); ok {
+		r.Register()
+	}
+	return mr
+}
+
+// NewReaderWithRegistry attaches provided histogram to reader like NewReader
+// does, and additionally registers h under name and tags with reg, so it is
+// included in every sample batch reg gathers while the reader remains open.
+// Close unregisters name from reg.
+func NewReaderWithRegistry(reader io.Reader, h Histogram, reg Registry, name string, tags map[string]string) *Reader {
+	mr := NewReader(reader, h)
+	mr.reg = reg
+	mr.name = name
+	reg.Register(name, h, tags)
 	return mr
 }
 
 // Read implements io.Reader interface; each read operation is timed and sampled
-// in attached histogram. Samples are stored in nanoseconds.
+// in attached histogram, and its byte count marked in attached meter.
+// Samples are stored in nanoseconds.
 func (mr *Reader) Read(p []byte) (n int, err error) {
 	var start time.Time
 	if mr.h != nil {
 		start = time.Now()
 	}
 	n, err = mr.Reader.Read(p)
-	if n > 0 && mr.h != nil {
-		mr.h.Update(time.Now().Sub(start).Nanoseconds())
+	if n > 0 {
+		if mr.h != nil {
+			mr.h.Update(time.Now().Sub(start).Nanoseconds())
+		}
+		if mr.m != nil {
+			mr.m.Mark(int64(n))
+		}
+	}
+	return n, err
+}
+
+// WriteTo implements io.WriterTo when the wrapped reader supports it,
+// preserving zero-copy fast paths (e.g. sendfile/splice on *os.File or
+// *net.TCPConn) that io.Copy would otherwise skip by falling back to a
+// user-space buffer loop. One latency sample is recorded for the whole
+// fast-path call, and its total byte count marked into the attached meter.
+// If the wrapped reader doesn't implement io.WriterTo, WriteTo falls back to
+// a generic copy loop that still samples latency/bytes per Read call.
+func (mr *Reader) WriteTo(w io.Writer) (n int64, err error) {
+	wt, ok := mr.Reader.(io.WriterTo)
+	if !ok {
+		return genericWriteTo(mr, w)
+	}
+	var start time.Time
+	if mr.h != nil {
+		start = time.Now()
+	}
+	n, err = wt.WriteTo(w)
+	if n > 0 {
+		if mr.h != nil {
+			mr.h.Update(time.Now().Sub(start).Nanoseconds())
+		}
+		if mr.m != nil {
+			mr.m.Mark(n)
+		}
+	}
+	return n, err
+}
+
+// genericWriteTo copies from r to w using a buffer, the same way io.Copy's
+// fallback path does when r doesn't implement io.WriterTo.
+func genericWriteTo(r io.Reader, w io.Writer) (n int64, err error) {
+	buf := make([]byte, 32*1024)
+	for {
+		nr, er := r.Read(buf)
+		if nr > 0 {
+			nw, ew := w.Write(buf[:nr])
+			n += int64(nw)
+			if ew != nil {
+				err = ew
+				break
+			}
+			if nr != nw {
+				err = io.ErrShortWrite
+				break
+			}
+		}
+		if er != nil {
+			if er != io.EOF {
+				err = er
+			}
+			break
+		}
 	}
 	return n, err
 }
 
 // Close implements io.Closer interface. If underlying reader implements
 // io.Closer, calling this method would also close it. If attached histogram
-// also implements Registrar interface, this would call its Done() method.
+// or meter also implements Registrar interface, this would call its Done()
+// method.
 func (mr *Reader) Close() error {
 	if mr.closed {
 		return nil
@@ -52,6 +143,12 @@ func (mr *Reader) Close() error {
 	if r, ok := mr.h.(Registrar); ok {
 		r.Done()
 	}
+	if r, ok := mr.m.(Registrar); ok {
+		r.Done()
+	}
+	if mr.reg != nil {
+		mr.reg.Unregister(mr.name)
+	}
 	if c, ok := mr.Reader.(io.Closer); ok {
 		return c.Close()
 	}
@@ -119,6 +119,73 @@ func TestReaderSelfCleaning(t *testing.T) {
 	}
 }
 
+// fakeMeter is a minimal Meter used only to verify that NewReaderWithMeter
+// marks read byte counts; it doesn't attempt real EWMA rate tracking.
+type fakeMeter struct {
+	count int64
+}
+
+func (m *fakeMeter) Mark(n int64)    { m.count += n }
+func (m *fakeMeter) Count() int64    { return m.count }
+func (m *fakeMeter) Rate1() float64  { return 0 }
+func (m *fakeMeter) Rate5() float64  { return 0 }
+func (m *fakeMeter) Rate15() float64 { return 0 }
+
+func TestReaderWithMeter(t *testing.T) {
+	histogram := metrics.NewHistogram(metrics.NewUniformSample(100))
+	meter := &fakeMeter{}
+	file, err := os.Open(os.Args[0])
+	if err != nil {
+		t.Fatal("failed to open file:", err)
+	}
+	defer file.Close()
+	r := io.LimitReader(file, 1<<19)
+	mr := NewReaderWithMeter(r, histogram, meter)
+	n, err := io.Copy(ioutil.Discard, mr)
+	if err != nil {
+		t.Fatal("failed to copy data:", err)
+	}
+	if meter.Count() != n {
+		t.Fatalf("meter recorded %d bytes, want %d", meter.Count(), n)
+	}
+}
+
+// fakeRegistry is a minimal Registry used only to verify that
+// NewReaderWithRegistry registers on construction and unregisters on Close.
+type fakeRegistry struct {
+	registered map[string]Histogram
+}
+
+func (reg *fakeRegistry) Register(name string, h Histogram, tags map[string]string) {
+	if reg.registered == nil {
+		reg.registered = make(map[string]Histogram)
+	}
+	reg.registered[name] = h
+}
+
+func (reg *fakeRegistry) Unregister(name string) {
+	delete(reg.registered, name)
+}
+
+func TestReaderWithRegistry(t *testing.T) {
+	histogram := metrics.NewHistogram(metrics.NewUniformSample(100))
+	reg := &fakeRegistry{}
+	file, err := os.Open(os.Args[0])
+	if err != nil {
+		t.Fatal("failed to open file:", err)
+	}
+	mr := NewReaderWithRegistry(file, histogram, reg, "test-reader", map[string]string{"path": os.Args[0]})
+	if _, ok := reg.registered["test-reader"]; !ok {
+		t.Fatal("expected reader's histogram to be registered under its name")
+	}
+	if err := mr.Close(); err != nil {
+		t.Fatal("metered reader close error:", err)
+	}
+	if _, ok := reg.registered["test-reader"]; ok {
+		t.Fatal("expected reader's histogram to be unregistered after Close")
+	}
+}
+
 func TestReaderDoubleClose(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
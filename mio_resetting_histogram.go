@@ -0,0 +1,205 @@
+package mio
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ResettingHistogramSnapshot is an immutable summary of every sample
+// recorded by a ResettingHistogram during one reporting interval.
+type ResettingHistogramSnapshot struct {
+	Count         int64
+	Min, Max      int64
+	Mean          float64
+	P50, P95, P99 float64
+}
+
+// ResettingHistogram keeps every raw sample received during a fixed
+// reporting interval, instead of keeping them until a period of inactivity
+// the way SelfCleaningHistogram does. On each tick of the reporting interval the
+// accumulated samples are sorted, summarized into a
+// ResettingHistogramSnapshot readable via Snapshot, and retained (rather
+// than discarded) so that Percentile can still answer arbitrary quantiles
+// exactly until the next tick overwrites them. This gives exact percentiles
+// over each window and is safe to feed to push-based reporters.
+// ResettingHistogram implements both Histogram and Registrar, serving its
+// statistical queries from the most recently published snapshot.
+type ResettingHistogram struct {
+	mu      sync.Mutex
+	samples []int64
+
+	published atomic.Value // resettingHistogramPublished
+
+	t      *time.Ticker
+	done   chan struct{}
+	closed bool
+}
+
+// resettingHistogramPublished bundles a ResettingHistogramSnapshot with the
+// sorted samples it was computed from, so Percentile can compute exact
+// arbitrary quantiles rather than being limited to the snapshot's
+// precomputed P50/P95/P99.
+type resettingHistogramPublished struct {
+	snapshot ResettingHistogramSnapshot
+	sorted   []int64
+}
+
+// NewResettingHistogram returns a ResettingHistogram that computes and
+// publishes a ResettingHistogramSnapshot every interval and starts a fresh
+// sample buffer for the next one; the previous interval's samples are kept
+// only until the following tick replaces them. Call Shutdown once the histogram is no
+// longer needed to stop its background goroutine.
+func NewResettingHistogram(interval time.Duration) *ResettingHistogram {
+	h := &ResettingHistogram{
+		t:    time.NewTicker(interval),
+		done: make(chan struct{}),
+	}
+	h.published.Store(resettingHistogramPublished{})
+	go h.run()
+	return h
+}
+
+func (h *ResettingHistogram) run() {
+	for {
+		select {
+		case <-h.t.C:
+			h.tick()
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// tick swaps in a fresh, empty sample slice and publishes a snapshot and its
+// sorted samples, computed over the samples collected since the previous
+// tick.
+func (h *ResettingHistogram) tick() {
+	h.mu.Lock()
+	samples := h.samples
+	h.samples = nil
+	h.mu.Unlock()
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	h.published.Store(resettingHistogramPublished{
+		snapshot: summarize(samples),
+		sorted:   samples,
+	})
+}
+
+// summarize computes a ResettingHistogramSnapshot over samples, which must
+// already be sorted in ascending order.
+func summarize(samples []int64) ResettingHistogramSnapshot {
+	if len(samples) == 0 {
+		return ResettingHistogramSnapshot{}
+	}
+	var sum int64
+	for _, s := range samples {
+		sum += s
+	}
+	return ResettingHistogramSnapshot{
+		Count: int64(len(samples)),
+		Min:   samples[0],
+		Max:   samples[len(samples)-1],
+		Mean:  float64(sum) / float64(len(samples)),
+		P50:   quantile(samples, 0.50),
+		P95:   quantile(samples, 0.95),
+		P99:   quantile(samples, 0.99),
+	}
+}
+
+// quantile computes the q-th quantile of sorted samples via linear
+// interpolation between closest ranks.
+func quantile(sorted []int64, q float64) float64 {
+	if len(sorted) == 1 {
+		return float64(sorted[0])
+	}
+	idx := q * float64(len(sorted)-1)
+	lo := int(idx)
+	hi := lo + 1
+	if hi > len(sorted)-1 {
+		return float64(sorted[lo])
+	}
+	frac := idx - float64(lo)
+	return float64(sorted[lo])*(1-frac) + float64(sorted[hi])*frac
+}
+
+// Update implements the Histogram interface, recording a new sample to be
+// included in the next snapshot.
+func (h *ResettingHistogram) Update(v int64) {
+	h.mu.Lock()
+	h.samples = append(h.samples, v)
+	h.mu.Unlock()
+}
+
+// Snapshot returns the summary computed at the last tick of the reporting
+// interval. Until the first tick fires, it returns a zero-value snapshot.
+func (h *ResettingHistogram) Snapshot() ResettingHistogramSnapshot {
+	return h.published.Load().(resettingHistogramPublished).snapshot
+}
+
+// Clear discards all samples collected since the last tick, without waiting
+// for the ticker to fire.
+func (h *ResettingHistogram) Clear() {
+	h.mu.Lock()
+	h.samples = nil
+	h.mu.Unlock()
+}
+
+// Count, Min, Max and Mean report values from the most recently published
+// Snapshot, implementing the Histogram interface for compatibility with
+// NewReader and NewWriter.
+func (h *ResettingHistogram) Count() int64  { return h.Snapshot().Count }
+func (h *ResettingHistogram) Min() int64    { return h.Snapshot().Min }
+func (h *ResettingHistogram) Max() int64    { return h.Snapshot().Max }
+func (h *ResettingHistogram) Mean() float64 { return h.Snapshot().Mean }
+
+// Percentile computes the p-th quantile exactly from the samples retained
+// from the last tick of the reporting interval, the same way Snapshot's
+// P50/P95/P99 are computed; p need not be 0.50, 0.95 or 0.99. Until the
+// first tick fires, or if that tick saw no samples, it returns 0.
+func (h *ResettingHistogram) Percentile(p float64) float64 {
+	sorted := h.published.Load().(resettingHistogramPublished).sorted
+	if len(sorted) == 0 {
+		return 0
+	}
+	return quantile(sorted, p)
+}
+
+// Percentiles reports Percentile(p) for each p in ps.
+func (h *ResettingHistogram) Percentiles(ps []float64) []float64 {
+	out := make([]float64, len(ps))
+	for i, p := range ps {
+		out[i] = h.Percentile(p)
+	}
+	return out
+}
+
+// StdDev and Variance are not tracked by ResettingHistogram's snapshot; they
+// always report 0.
+func (h *ResettingHistogram) StdDev() float64   { return 0 }
+func (h *ResettingHistogram) Variance() float64 { return 0 }
+
+// Register implements the Registrar interface. ResettingHistogram cleans
+// itself on a fixed timer rather than tracking concurrent users, so this is
+// a no-op.
+func (h *ResettingHistogram) Register() {}
+
+// Done implements the Registrar interface; see Register.
+func (h *ResettingHistogram) Done() {}
+
+// Shutdown implements the Registrar interface, stopping the background
+// ticker goroutine. Calling Shutdown more than once is a no-op, matching
+// SelfCleaningHistogram.Shutdown.
+func (h *ResettingHistogram) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.closed {
+		h.closed = true
+		h.t.Stop()
+		close(h.done)
+	}
+}
+
+var _ Histogram = (*ResettingHistogram)(nil)
+var _ Registrar = (*ResettingHistogram)(nil)
@@ -0,0 +1,71 @@
+package mio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResettingHistogram(t *testing.T) {
+	h := NewResettingHistogram(50 * time.Millisecond)
+	defer h.Shutdown()
+
+	for i := int64(1); i <= 100; i++ {
+		h.Update(i)
+	}
+
+	// Wake between the first tick (50ms) and the second (100ms), which would
+	// publish an empty snapshot.
+	time.Sleep(75 * time.Millisecond)
+	snap := h.Snapshot()
+	if snap.Count != 100 {
+		t.Fatalf("snapshot count = %d, want 100", snap.Count)
+	}
+	if snap.Min != 1 || snap.Max != 100 {
+		t.Fatalf("snapshot min/max = %d/%d, want 1/100", snap.Min, snap.Max)
+	}
+	if snap.P50 < 49 || snap.P50 > 51 {
+		t.Fatalf("snapshot P50 = %v, want ~50", snap.P50)
+	}
+	if h.Count() != snap.Count {
+		t.Fatalf("Count() = %d, want snapshot count %d", h.Count(), snap.Count)
+	}
+
+	// Samples since the last tick should not leak into the next snapshot
+	// until the ticker fires again.
+	h.Update(1000)
+	if h.Count() != snap.Count {
+		t.Fatalf("Count() changed before next tick: got %d, want %d", h.Count(), snap.Count)
+	}
+
+	// Percentile must compute an arbitrary quantile exactly from the
+	// retained samples, not round it up to the nearest of P50/P95/P99.
+	if p90 := h.Percentile(0.90); p90 < 89 || p90 > 91 {
+		t.Fatalf("Percentile(0.90) = %v, want ~90", p90)
+	}
+}
+
+func TestResettingHistogramDoubleShutdown(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatal("double Shutdown caused panic:", r)
+		}
+	}()
+	h := NewResettingHistogram(time.Hour)
+	h.Shutdown()
+	h.Shutdown()
+}
+
+func TestResettingHistogramClear(t *testing.T) {
+	h := NewResettingHistogram(time.Hour)
+	defer h.Shutdown()
+
+	h.Update(1)
+	h.Update(2)
+	h.Clear()
+	h.mu.Lock()
+	n := len(h.samples)
+	h.mu.Unlock()
+	if n != 0 {
+		t.Fatalf("samples after Clear() = %d, want 0", n)
+	}
+}
@@ -10,6 +10,9 @@ import (
 type Writer struct {
 	io.Writer
 	h      Histogram
+	m      Meter
+	reg    Registry
+	name   string
 	closed bool
 }
 
@@ -17,33 +20,121 @@ type Writer struct {
 // io.Writer. If histogram implements Registrar interface, this would also call
 // its Register() method.
 func NewWriter(writer io.Writer, h Histogram) *Writer {
+	return NewWriterWithMeter(writer, h, nil)
+}
+
+// NewWriterWithMeter attaches provided histogram and meter to writer,
+// returning new io.Writer. Each successful Write samples its latency into h
+// and marks its byte count into m. If h or m implements Registrar interface,
+// this would also call its Register() method; m may be nil to skip
+// throughput tracking.
+func NewWriterWithMeter(writer io.Writer, h Histogram, m Meter) *Writer {
 	mw := &Writer{
 		Writer: writer,
 		h:      h,
+		m:      m,
 	}
 	if r, ok := h.(Registrar); ok {
 		r.Register()
 	}
+	if r, ok := m.(Registrar); ok {
+		r.Register()
+	}
+	return mw
+}
+
+// NewWriterWithRegistry attaches provided histogram to writer like NewWriter
+// does, and additionally registers h under name and tags with reg, so it is
+// included in every sample batch reg gathers while the writer remains open.
+// Close unregisters name from reg.
+func NewWriterWithRegistry(writer io.Writer, h Histogram, reg Registry, name string, tags map[string]string) *Writer {
+	mw := NewWriter(writer, h)
+	mw.reg = reg
+	mw.name = name
+	reg.Register(name, h, tags)
 	return mw
 }
 
 // Write implements io.Writer interface; each write operation is timed and
-// sampled in attached histogram. Samples are stored in nanoseconds.
+// sampled in attached histogram, and its byte count marked in attached
+// meter. Samples are stored in nanoseconds.
 func (mw *Writer) Write(p []byte) (n int, err error) {
 	var start time.Time
 	if mw.h != nil {
 		start = time.Now()
 	}
 	n, err = mw.Writer.Write(p)
-	if n > 0 && mw.h != nil {
-		mw.h.Update(time.Now().Sub(start).Nanoseconds())
+	if n > 0 {
+		if mw.h != nil {
+			mw.h.Update(time.Now().Sub(start).Nanoseconds())
+		}
+		if mw.m != nil {
+			mw.m.Mark(int64(n))
+		}
+	}
+	return n, err
+}
+
+// ReadFrom implements io.ReaderFrom when the wrapped writer supports it,
+// preserving zero-copy fast paths (e.g. sendfile/splice on *os.File or
+// *net.TCPConn) that io.Copy would otherwise skip by falling back to a
+// user-space buffer loop. One latency sample is recorded for the whole
+// fast-path call, and its total byte count marked into the attached meter.
+// If the wrapped writer doesn't implement io.ReaderFrom, ReadFrom falls back
+// to a generic copy loop that still samples latency/bytes per Write call.
+func (mw *Writer) ReadFrom(r io.Reader) (n int64, err error) {
+	rf, ok := mw.Writer.(io.ReaderFrom)
+	if !ok {
+		return genericReadFrom(mw, r)
+	}
+	var start time.Time
+	if mw.h != nil {
+		start = time.Now()
+	}
+	n, err = rf.ReadFrom(r)
+	if n > 0 {
+		if mw.h != nil {
+			mw.h.Update(time.Now().Sub(start).Nanoseconds())
+		}
+		if mw.m != nil {
+			mw.m.Mark(n)
+		}
+	}
+	return n, err
+}
+
+// genericReadFrom copies from r to w using a buffer, the same way
+// io.Copy's fallback path does when w doesn't implement io.ReaderFrom.
+func genericReadFrom(w io.Writer, r io.Reader) (n int64, err error) {
+	buf := make([]byte, 32*1024)
+	for {
+		nr, er := r.Read(buf)
+		if nr > 0 {
+			nw, ew := w.Write(buf[:nr])
+			n += int64(nw)
+			if ew != nil {
+				err = ew
+				break
+			}
+			if nr != nw {
+				err = io.ErrShortWrite
+				break
+			}
+		}
+		if er != nil {
+			if er != io.EOF {
+				err = er
+			}
+			break
+		}
 	}
 	return n, err
 }
 
 // Close implements io.Closer interface. If underlying writer implements
 // io.Closer, calling this method would also close it. If attached histogram
-// also implements Registrar interface, this would call its Done() method.
+// or meter also implements Registrar interface, this would call its Done()
+// method.
 func (mw *Writer) Close() error {
 	if mw.closed {
 		return nil
@@ -52,6 +143,12 @@ func (mw *Writer) Close() error {
 	if r, ok := mw.h.(Registrar); ok {
 		r.Done()
 	}
+	if r, ok := mw.m.(Registrar); ok {
+		r.Done()
+	}
+	if mw.reg != nil {
+		mw.reg.Unregister(mw.name)
+	}
 	if c, ok := mw.Writer.(io.Closer); ok {
 		return c.Close()
 	}
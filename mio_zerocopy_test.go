@@ -0,0 +1,49 @@
+package mio
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+var errSentinel = errors.New("fast path taken")
+
+// sentinelReaderFrom is an io.Writer that also implements io.ReaderFrom,
+// returning errSentinel only from ReadFrom, so tests can tell whether
+// io.Copy took the io.ReaderFrom fast path or fell back to Write calls.
+type sentinelReaderFrom struct{}
+
+func (sentinelReaderFrom) Write(p []byte) (int, error) { return len(p), nil }
+func (sentinelReaderFrom) ReadFrom(io.Reader) (int64, error) {
+	return 0, errSentinel
+}
+
+// sentinelWriterTo is an io.Reader that also implements io.WriterTo,
+// returning errSentinel only from WriteTo, so tests can tell whether
+// io.Copy took the io.WriterTo fast path or fell back to Read calls.
+type sentinelWriterTo struct{}
+
+func (sentinelWriterTo) Read(p []byte) (int, error) { return len(p), nil }
+func (sentinelWriterTo) WriteTo(io.Writer) (int64, error) {
+	return 0, errSentinel
+}
+
+func TestWriterPreservesReadFromFastPath(t *testing.T) {
+	mw := NewWriter(sentinelReaderFrom{}, nil)
+	// io.Copy prefers the source's io.WriterTo over the destination's
+	// io.ReaderFrom, so hide strings.Reader's WriteTo behind a plain io.Reader.
+	_, err := io.Copy(mw, struct{ io.Reader }{strings.NewReader("hello")})
+	if err != errSentinel {
+		t.Fatalf("io.Copy error = %v, want errSentinel (ReadFrom fast path not taken)", err)
+	}
+}
+
+func TestReaderPreservesWriteToFastPath(t *testing.T) {
+	mr := NewReader(sentinelWriterTo{}, nil)
+	_, err := io.Copy(ioutil.Discard, mr)
+	if err != errSentinel {
+		t.Fatalf("io.Copy error = %v, want errSentinel (WriteTo fast path not taken)", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Package prom provides a mio.Histogram implementation that reports samples
+// to a Prometheus HistogramVec or SummaryVec, so streams wrapped by
+// mio.NewReader or mio.NewWriter can feed a scrape endpoint directly,
+// without an extra reporter loop in between.
+package prom
+
+import (
+	"github.com/artyom/metrics"
+	"github.com/asardak/mio"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// reservoirSize bounds the local sample reservoir kept for statistical
+// queries; it's an arbitrary default, not sized to any particular error
+// bound.
+const reservoirSize = 1024
+
+// Histogram adapts a prometheus.ObserverVec (a *prometheus.HistogramVec or
+// *prometheus.SummaryVec) bound to a fixed set of label values to the
+// mio.Histogram interface. Update reports each sample, in seconds, to the
+// Prometheus vector, and also feeds a local reservoir so that Count, Mean,
+// Percentile and the other statistical queries mio.Histogram callers rely
+// on keep working the same way they do over the rcrowley implementation.
+//
+// Histogram also implements mio.Registrar. If NewHistogram was given a
+// non-nil gaugeVec, Register and Done increment and decrement the gauge bound
+// to the same labels, tracking the number of in-flight streams sharing this
+// Histogram; otherwise they do nothing.
+type Histogram struct {
+	metrics.Histogram
+	obs      prometheus.Observer
+	inFlight prometheus.Gauge
+}
+
+// NewHistogram returns a Histogram observing into vec (a *HistogramVec or
+// *SummaryVec) using labels. If gaugeVec is non-nil, it is used to track
+// in-flight stream counts via the Registrar interface; pass nil if that
+// tracking isn't needed.
+func NewHistogram(vec prometheus.ObserverVec, gaugeVec *prometheus.GaugeVec, labels prometheus.Labels) *Histogram {
+	h := &Histogram{
+		Histogram: metrics.NewHistogram(metrics.NewUniformSample(reservoirSize)),
+		obs:       vec.With(labels),
+	}
+	if gaugeVec != nil {
+		h.inFlight = gaugeVec.With(labels)
+	}
+	return h
+}
+
+// Update implements the mio.Histogram interface; ns is a latency sample in
+// nanoseconds, as passed by mio.Writer and mio.Reader.
+func (h *Histogram) Update(ns int64) {
+	h.Histogram.Update(ns)
+	h.obs.Observe(float64(ns) / 1e9)
+}
+
+// Register implements the mio.Registrar interface, incrementing the
+// in-flight gauge if one was provided to NewHistogram.
+func (h *Histogram) Register() {
+	if h.inFlight != nil {
+		h.inFlight.Inc()
+	}
+}
+
+// Done implements the mio.Registrar interface, decrementing the in-flight
+// gauge if one was provided to NewHistogram.
+func (h *Histogram) Done() {
+	if h.inFlight != nil {
+		h.inFlight.Dec()
+	}
+}
+
+// Shutdown implements the mio.Registrar interface. Histogram has no
+// background goroutines to stop, so this is a no-op.
+func (h *Histogram) Shutdown() {}
+
+var _ mio.Histogram = (*Histogram)(nil)
+var _ mio.Registrar = (*Histogram)(nil)
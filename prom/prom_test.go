@@ -0,0 +1,38 @@
+package prom
+
+import (
+	"testing"
+
+	"github.com/asardak/mio"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHistogramObservesAndTracksInFlight(t *testing.T) {
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "test_latency_seconds",
+	}, []string{"path", "op"})
+	gaugeVec := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "test_inflight_streams",
+	}, []string{"path", "op"})
+	labels := prometheus.Labels{"path": "/foo", "op": "read"}
+
+	h := NewHistogram(vec, gaugeVec, labels)
+	var r mio.Registrar = h
+	r.Register()
+
+	gauge := gaugeVec.With(labels)
+	if got := testutil.ToFloat64(gauge); got != 1 {
+		t.Fatalf("in-flight gauge = %v, want 1", got)
+	}
+
+	h.Update(1500000) // 1.5ms
+	if h.Count() != 1 {
+		t.Fatalf("local reservoir Count() = %d, want 1", h.Count())
+	}
+
+	r.Done()
+	if got := testutil.ToFloat64(gauge); got != 0 {
+		t.Fatalf("in-flight gauge after Done() = %v, want 0", got)
+	}
+}
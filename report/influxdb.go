@@ -0,0 +1,99 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InfluxDBReporter writes Samples to an InfluxDB HTTP write endpoint, such
+// as "http://localhost:8086/write?db=mydb", using the line protocol.
+type InfluxDBReporter struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewInfluxDBReporter returns an InfluxDBReporter posting to url.
+func NewInfluxDBReporter(url string) *InfluxDBReporter {
+	return &InfluxDBReporter{URL: url}
+}
+
+// Report implements the Reporter interface, encoding samples as InfluxDB
+// line protocol and POSTing them to URL in a single request.
+func (rep *InfluxDBReporter) Report(ctx context.Context, samples []Sample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, s := range samples {
+		writeLine(&buf, s)
+	}
+	req, err := http.NewRequest(http.MethodPost, rep.URL, &buf)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	client := rep.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("report: influxdb write failed: %s", resp.Status)
+	}
+	return nil
+}
+
+// writeLine appends s to buf as a single InfluxDB line protocol point:
+// measurement,tag=value field=value,field=value
+func writeLine(buf *bytes.Buffer, s Sample) {
+	buf.WriteString(escape(s.Name))
+	tagKeys := make([]string, 0, len(s.Tags))
+	for k := range s.Tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		buf.WriteByte(',')
+		buf.WriteString(escape(k))
+		buf.WriteByte('=')
+		buf.WriteString(escape(s.Tags[k]))
+	}
+	buf.WriteByte(' ')
+
+	fields := []string{
+		"count=" + strconv.FormatInt(s.Count, 10) + "i",
+		"min=" + strconv.FormatInt(s.Min, 10) + "i",
+		"max=" + strconv.FormatInt(s.Max, 10) + "i",
+		"mean=" + strconv.FormatFloat(s.Mean, 'f', -1, 64),
+		"stddev=" + strconv.FormatFloat(s.StdDev, 'f', -1, 64),
+	}
+	pKeys := make([]float64, 0, len(s.Percentiles))
+	for p := range s.Percentiles {
+		pKeys = append(pKeys, p)
+	}
+	sort.Float64s(pKeys)
+	for _, p := range pKeys {
+		field := fmt.Sprintf("p%g=%s", p*100, strconv.FormatFloat(s.Percentiles[p], 'f', -1, 64))
+		fields = append(fields, field)
+	}
+	buf.WriteString(strings.Join(fields, ","))
+	buf.WriteByte('\n')
+}
+
+// escape replaces characters the line protocol treats specially (commas,
+// spaces, equals signs) with their escaped form.
+func escape(s string) string {
+	r := strings.NewReplacer(",", "\\,", " ", "\\ ", "=", "\\=")
+	return r.Replace(s)
+}
+
+var _ Reporter = (*InfluxDBReporter)(nil)
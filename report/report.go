@@ -0,0 +1,131 @@
+// Package report periodically gathers summaries from named Histogram
+// instances and flushes them to a metrics backend, so streams wrapped by
+// mio.NewReader/mio.NewWriter can push their statistics to a backend like
+// InfluxDB or Graphite without an external scraper.
+package report
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/asardak/mio"
+)
+
+// DefaultPercentiles are the percentiles included in each Sample unless a
+// Registry is constructed with a different set via NewRegistry.
+var DefaultPercentiles = []float64{0.5, 0.95, 0.99}
+
+// Sample is a point-in-time summary of one registered Histogram, ready to
+// be handed to a Reporter.
+type Sample struct {
+	Name        string
+	Tags        map[string]string
+	Count       int64
+	Min, Max    int64
+	Mean        float64
+	StdDev      float64
+	Percentiles map[float64]float64
+}
+
+// Reporter flushes a batch of Samples gathered by a Registry to a metrics
+// backend.
+type Reporter interface {
+	Report(ctx context.Context, samples []Sample) error
+}
+
+type entry struct {
+	name string
+	tags map[string]string
+	h    mio.Histogram
+}
+
+// Registry tracks named Histogram instances and periodically summarizes
+// them into Samples for a Reporter to flush. A Registry is safe for
+// concurrent use, and also satisfies the mio.Registry interface, so it can
+// be passed directly to mio.NewWriterWithRegistry/mio.NewReaderWithRegistry.
+type Registry struct {
+	percentiles []float64
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewRegistry returns an empty Registry reporting the given percentiles for
+// each sample; if percentiles is empty, DefaultPercentiles is used.
+func NewRegistry(percentiles ...float64) *Registry {
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
+	return &Registry{percentiles: percentiles, entries: make(map[string]*entry)}
+}
+
+// Register attaches h under name and tags, so it is included in every
+// sample batch gathered while it remains registered. Registering the same
+// name again replaces the previous entry.
+func (r *Registry) Register(name string, h mio.Histogram, tags map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[name] = &entry{name: name, tags: tags, h: h}
+}
+
+// Unregister removes name from the registry; subsequent gathers no longer
+// include it.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, name)
+}
+
+// gather builds one Sample per registered entry, reading each Histogram's
+// current statistics.
+func (r *Registry) gather() []Sample {
+	r.mu.Lock()
+	entries := make([]*entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e)
+	}
+	r.mu.Unlock()
+
+	samples := make([]Sample, 0, len(entries))
+	for _, e := range entries {
+		pcts := make(map[float64]float64, len(r.percentiles))
+		for i, v := range e.h.Percentiles(r.percentiles) {
+			pcts[r.percentiles[i]] = v
+		}
+		samples = append(samples, Sample{
+			Name:        e.name,
+			Tags:        e.tags,
+			Count:       e.h.Count(),
+			Min:         e.h.Min(),
+			Max:         e.h.Max(),
+			Mean:        e.h.Mean(),
+			StdDev:      e.h.StdDev(),
+			Percentiles: pcts,
+		})
+	}
+	return samples
+}
+
+// Start runs a loop gathering samples from r every interval and handing
+// them to rep.Report, until ctx is canceled. A failed Report call is logged
+// and otherwise ignored; it does not stop the loop.
+func (r *Registry) Start(ctx context.Context, rep Reporter, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if samples := r.gather(); len(samples) > 0 {
+				if err := rep.Report(ctx, samples); err != nil {
+					log.Printf("report: failed to report %d samples: %v", len(samples), err)
+				}
+			}
+		}
+	}
+}
+
+var _ mio.Registry = (*Registry)(nil)
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artyom/metrics"
+)
+
+func TestRegistryGatherAndInfluxDBReport(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	h := metrics.NewHistogram(metrics.NewUniformSample(100))
+	h.Update(1)
+	h.Update(2)
+	h.Update(3)
+
+	reg := NewRegistry()
+	reg.Register("reads", h, map[string]string{"path": "/foo"})
+
+	rep := NewInfluxDBReporter(srv.URL)
+	samples := reg.gather()
+	if len(samples) != 1 {
+		t.Fatalf("gathered %d samples, want 1", len(samples))
+	}
+	if samples[0].Count != 3 {
+		t.Fatalf("sample count = %d, want 3", samples[0].Count)
+	}
+
+	if err := rep.Report(context.Background(), samples); err != nil {
+		t.Fatal("Report failed:", err)
+	}
+	if !strings.HasPrefix(body, "reads,path=/foo ") {
+		t.Fatalf("unexpected line protocol body: %q", body)
+	}
+
+	reg.Unregister("reads")
+	if samples := reg.gather(); len(samples) != 0 {
+		t.Fatalf("gathered %d samples after Unregister, want 0", len(samples))
+	}
+}